@@ -0,0 +1,39 @@
+package gophen
+
+import "fmt"
+
+// Load resolves language to a dictionary name via LanguageFallback and
+// returns its HyphDict, loading and memoizing it in hdcache on first use.
+// Concurrent calls for different languages, or the same one, are safe.
+func Load(language string) (*HyphDict, error) {
+	name := LanguageFallback(language)
+	if name == "" {
+		return nil, fmt.Errorf("gophen: no dictionary available for language %q", language)
+	}
+
+	if hd, ok := hdcache.Load(name); ok {
+		return hd.(*HyphDict), nil
+	}
+
+	languageMu.RLock()
+	path, ok := LANGUAGES[name]
+	if !ok || path == "" {
+		// name may be a full dictionary tag (e.g. "en_GB") rather than the
+		// bare base-language code LANGUAGES is keyed by; dictionaryTags
+		// holds every dictionary discovered by populateLanguages under its
+		// full name.
+		path, ok = dictionaryTags[name]
+	}
+	languageMu.RUnlock()
+	if !ok || path == "" {
+		return nil, fmt.Errorf("gophen: no loadable dictionary registered for language %q", name)
+	}
+
+	hd, err := NewHyphDict(path)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := hdcache.LoadOrStore(name, hd)
+	return actual.(*HyphDict), nil
+}
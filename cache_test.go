@@ -0,0 +1,56 @@
+package gophen
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLoadHappyPath(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\na1n1\n")
+	registerTestLanguage(t, "fr_FR", "french", hd)
+
+	got, err := Load("fr-FR")
+	if err != nil {
+		t.Fatalf("Load(%q): %v", "fr-FR", err)
+	}
+	if got != hd {
+		t.Fatalf("Load(%q) returned a different *HyphDict than was registered", "fr-FR")
+	}
+
+	// A second call must come back from hdcache rather than erroring or
+	// constructing a new dictionary.
+	got2, err := Load("french")
+	if err != nil {
+		t.Fatalf("Load(%q): %v", "french", err)
+	}
+	if got2 != hd {
+		t.Fatalf("Load(%q) returned a different *HyphDict than was registered", "french")
+	}
+}
+
+func TestLoadUnknownLanguage(t *testing.T) {
+	if _, err := Load("xx-not-a-real-language"); err == nil {
+		t.Fatal("Load with an unregistered language = nil error, want an error")
+	}
+}
+
+func TestLoadConcurrentAccess(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\na1n1\n")
+	registerTestLanguage(t, "nl_NL", "dutch", hd)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lang := "nl-NL"
+			if i%2 == 0 {
+				lang = "dutch"
+			}
+			if _, err := Load(lang); err != nil {
+				t.Errorf("Load(%q): %v", lang, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,80 @@
+package gophen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/transform"
+)
+
+// libhyphenAliases covers the handful of libhyphen header spellings that
+// ianaindex doesn't resolve on its own.
+var libhyphenAliases = map[string]encoding.Encoding{
+	"microsoft-cp1250": charmap.Windows1250,
+	"microsoft-cp1251": charmap.Windows1251,
+	"microsoft-cp1252": charmap.Windows1252,
+	"koi8-r":           charmap.KOI8R,
+	// ianaindex resolves "iso-8859-11" to a nil encoding (no table), but
+	// libhyphen dictionary headers do use the name; charmap.Windows874 is
+	// TIS-620-compatible in the printable range and is what Thai
+	// dictionaries actually ship encoded as.
+	"iso8859-11":  charmap.Windows874,
+	"iso-8859-11": charmap.Windows874,
+}
+
+// isoWithoutDash matches libhyphen's unhyphenated "iso8859-N" header
+// spelling, which ianaindex only recognizes as "iso-8859-N".
+var isoWithoutDash = regexp.MustCompile(`^iso8859-(\d{1,2})$`)
+
+// resolveEncoding looks up the text encoding named by a dictionary header
+// line: "UTF-8", "ISO8859-1".."ISO8859-10" and "ISO8859-13".."ISO8859-16"
+// via ianaindex, "ISO8859-11" via the TIS-620-compatible charmap.Windows874
+// (ISO-8859-11 was never formally assigned a table), "microsoft-cp1250",
+// "microsoft-cp1251", "microsoft-cp1252", or "KOI8-R". There is no
+// "ISO8859-12": that part number was abandoned and never published.
+
+func resolveEncoding(name string) (encoding.Encoding, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "utf-8" || name == "utf8" {
+		return encoding.Nop, nil
+	}
+	if enc, ok := libhyphenAliases[name]; ok {
+		return enc, nil
+	}
+	if m := isoWithoutDash.FindStringSubmatch(name); m != nil {
+		name = "iso-8859-" + m[1]
+	}
+	if enc, err := ianaindex.IANA.Encoding(name); err == nil && enc != nil {
+		return enc, nil
+	}
+	return nil, fmt.Errorf("unknown dictionary encoding %q", name)
+}
+
+// decodeLines transcodes lines from the named encoding to UTF-8. A line
+// that fails to transcode is kept as-is, matching the best-effort recovery
+// the original cp1251-only loader used.
+func decodeLines(lines []string, name string) ([]string, error) {
+	enc, err := resolveEncoding(name)
+	if err != nil {
+		return nil, err
+	}
+	if enc == encoding.Nop {
+		return lines, nil
+	}
+
+	decoder := enc.NewDecoder()
+	decoded := make([]string, len(lines))
+	for i, line := range lines {
+		utf8Line, _, err := transform.String(decoder, line)
+		if err != nil {
+			decoded[i] = line
+			continue
+		}
+		decoded[i] = utf8Line
+	}
+	return decoded, nil
+}
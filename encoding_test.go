@@ -0,0 +1,23 @@
+package gophen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveEncodingISO8859(t *testing.T) {
+	for _, header := range []string{"ISO8859-1", "ISO8859-15", "iso-8859-1", "ISO8859-11"} {
+		data := header + "\n.ab1c.\na1n1\n"
+		if _, err := NewHyphDictFromReader(strings.NewReader(data), ""); err != nil {
+			t.Errorf("NewHyphDictFromReader with header %q: %v", header, err)
+		}
+	}
+}
+
+func TestResolveEncodingISO885912Unsupported(t *testing.T) {
+	// ISO-8859-12 was abandoned and never published; it must not silently
+	// resolve to some other table.
+	if _, err := resolveEncoding("ISO8859-12"); err == nil {
+		t.Fatal("resolveEncoding(\"ISO8859-12\") = nil error, want an error")
+	}
+}
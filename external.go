@@ -0,0 +1,98 @@
+package gophen
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// NewHyphDictFromReader parses a dictionary already held in memory. If
+// encoding is "", the first line read from r is treated as the libhyphen
+// encoding header exactly as in a .dic file; otherwise every line read
+// from r is treated as pattern data already labelled with encoding.
+func NewHyphDictFromReader(r io.Reader, encoding string) (*HyphDict, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary data: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	body := lines
+	if encoding == "" {
+		if len(lines) < 2 {
+			return nil, fmt.Errorf("invalid dictionary data: missing encoding header")
+		}
+		encoding = lines[0]
+		body = lines[1:]
+	}
+
+	decoded, err := decodeLines(body, encoding)
+	if err != nil {
+		return nil, err
+	}
+	return newHyphDictFromPatternLines(decoded)
+}
+
+// NewHyphDictFromFile reads a hyph_*.dic file from the local filesystem.
+func NewHyphDictFromFile(path string) (*HyphDict, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dictionary file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hd, err := NewHyphDictFromReader(file, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dictionary file %s: %w", path, err)
+	}
+	return hd, nil
+}
+
+// NewHyphDictFromFS reads a hyph_*.dic file from fsys, the way NewHyphDict
+// reads one from the package's embedded dictionaries.
+func NewHyphDictFromFS(fsys fs.FS, path string) (*HyphDict, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dictionary file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hd, err := NewHyphDictFromReader(file, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dictionary file %s: %w", path, err)
+	}
+	return hd, nil
+}
+
+// RegisterLanguage makes an already-loaded dictionary participate in
+// LanguageFallback under name (e.g. "de_DE_custom"), with alias as an
+// additional lowercase lookup key for LanguageFallback ("" to skip it). It
+// is how callers plug in dictionaries loaded via NewHyphDictFromReader/
+// File/FS instead of the embedded set. It is safe to call concurrently with
+// hyphenation and with other RegisterLanguage calls.
+//
+// name also joins the BCP-47 matcher MatchLanguage uses, but only if it
+// parses as a well-formed tag once underscores are replaced with hyphens
+// (e.g. "de_DE" or "sr_Latn"); a name that doesn't parse as one (e.g.
+// "de_DE_custom") is still reachable through LanguageFallback's lexical
+// fallback, it just never wins a MatchLanguage match.
+func RegisterLanguage(name, alias string, hd *HyphDict) {
+	hdcache.Store(name, hd)
+
+	languageMu.Lock()
+	defer languageMu.Unlock()
+
+	shortName := strings.Split(name, "_")[0]
+	if _, ok := LANGUAGES[shortName]; !ok {
+		LANGUAGES[shortName] = ""
+	}
+	dictionaryTags[name] = ""
+	languagesLowercase[strings.ToLower(name)] = name
+	if alias != "" {
+		languagesLowercase[strings.ToLower(alias)] = name
+	}
+
+	rebuildLanguageMatcherLocked()
+}
@@ -0,0 +1,48 @@
+package gophen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewHyphDictFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hyph_xx.dic")
+	data := []byte("UTF-8\n.ab1c.\na1n1\n")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("writing test dictionary: %v", err)
+	}
+
+	hd, err := NewHyphDictFromFile(path)
+	if err != nil {
+		t.Fatalf("NewHyphDictFromFile(%q): %v", path, err)
+	}
+	if got, want := hd.Positions("anan", WithLeftMin(1), WithRightMin(1)), []int{1, 2, 3}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v", "anan", got, want)
+	}
+
+	if _, err := NewHyphDictFromFile(filepath.Join(t.TempDir(), "missing.dic")); err == nil {
+		t.Fatal("NewHyphDictFromFile on a missing file = nil error, want an error")
+	}
+}
+
+func TestNewHyphDictFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dictionaries/hyph_xx.dic": &fstest.MapFile{
+			Data: []byte("UTF-8\n.ab1c.\na1n1\n"),
+		},
+	}
+
+	hd, err := NewHyphDictFromFS(fsys, "dictionaries/hyph_xx.dic")
+	if err != nil {
+		t.Fatalf("NewHyphDictFromFS: %v", err)
+	}
+	if got, want := hd.Positions("anan", WithLeftMin(1), WithRightMin(1)), []int{1, 2, 3}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v", "anan", got, want)
+	}
+
+	if _, err := NewHyphDictFromFS(fsys, "dictionaries/missing.dic"); err == nil {
+		t.Fatal("NewHyphDictFromFS on a missing path = nil error, want an error")
+	}
+}
@@ -2,32 +2,48 @@ package gophen
 
 import (
 	"embed"
+	"errors"
 	"fmt"
-	"io"
 	"io/fs"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
-	"unicode/utf8"
-
-	"golang.org/x/text/encoding/charmap"
-	"golang.org/x/text/transform"
+	"sync"
 )
 
 var dictionaries embed.FS
-var hdcache = make(map[string]*HyphDict)
+
+// hdcache memoizes dictionaries by name (the form LanguageFallback
+// returns), shared across goroutines via Load.
+var hdcache sync.Map // map[string]*HyphDict
 
 var (
 	parseHex = regexp.MustCompile(`\^{2}([0-9a-fA-F]{2})`)
 	parse    = regexp.MustCompile(`(\d?)(\D?)`)
 )
 
-var ignored = []string{"%", "#", "LEFTHYPHENMIN", "RIGHTHYPHENMIN", "COMPOUNDLEFTHYPHENMIN", "COMPOUNDRIGHTHYPHENMIN"}
+var ignored = []string{"%", "#"}
+
+// defaultHyphenMin is libhyphen's default minimum break distance from either
+// edge of a word when a dictionary doesn't specify one.
+const defaultHyphenMin = 2
 
 var LANGUAGES = make(map[string]string)
 var languagesLowercase = make(map[string]string)
 
+// dictionaryTags holds every dictionary name discovered by populateLanguages
+// (e.g. "en_GB", "pt_BR", "sr_Latn"), unlike LANGUAGES which keeps only the
+// first dictionary seen per base language. MatchLanguage uses the full set
+// to build its BCP-47 matcher.
+var dictionaryTags = make(map[string]string)
+
+// languageMu guards LANGUAGES, languagesLowercase, dictionaryTags, and the
+// language.go matcher globals, all of which RegisterLanguage can mutate at
+// any time (not just at init), concurrently with LanguageFallback/
+// MatchLanguage/Load reading them.
+var languageMu sync.RWMutex
+
 func init() {
 	if err := populateLanguages(); err != nil {
 		panic(fmt.Sprintf("Failed to populate languages: %v", err))
@@ -37,11 +53,22 @@ func init() {
 func populateLanguages() error {
 	dirEntries, err := fs.ReadDir(dictionaries, "dictionaries")
 	if err != nil {
+		// The package ships usable even with no embedded dictionaries (e.g.
+		// a build that only loads dictionaries via NewHyphDictFromReader/
+		// File/FS/LoadDict, or RegisterLanguage); only a real I/O failure
+		// against an existing embed is worth surfacing.
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
 		return err
 	}
 	sort.Slice(dirEntries, func(i, j int) bool {
 		return dirEntries[i].Name() < dirEntries[j].Name()
 	})
+
+	languageMu.Lock()
+	defer languageMu.Unlock()
+
 	for _, entry := range dirEntries {
 		if strings.HasSuffix(entry.Name(), ".dic") {
 			name := entry.Name()[5 : len(entry.Name())-4] // Remove "hyph_" prefix and ".dic" suffix
@@ -50,17 +77,41 @@ func populateLanguages() error {
 			if _, ok := LANGUAGES[shortName]; !ok {
 				LANGUAGES[shortName] = path
 			}
+			dictionaryTags[name] = path
 		}
 	}
 	for name := range LANGUAGES {
 		languagesLowercase[strings.ToLower(name)] = name
 	}
+	rebuildLanguageMatcherLocked()
 	return nil
 }
 
 // LanguageFallback gets a fallback language available in our dictionaries.
+// It first checks for an exact (case-insensitive) registration, then
+// delegates to MatchLanguage's BCP-47-aware matcher for proper script/
+// region fallback (e.g. "sr-Latn-RS" -> "sr_Latn", "en-AU" -> "en_GB").
+// Only if neither finds anything does it fall back to the coarse lexical
+// prefix-trimming match, which would otherwise short-circuit on a bare
+// base-language code (e.g. "en") before a more specific sibling (e.g.
+// "en_GB") ever gets a chance via MatchLanguage.
 func LanguageFallback(language string) string {
-	parts := strings.Split(strings.ReplaceAll(strings.ToLower(language), "-", "_"), "_")
+	normalized := strings.ReplaceAll(strings.ToLower(language), "-", "_")
+
+	languageMu.RLock()
+	exact, ok := languagesLowercase[normalized]
+	languageMu.RUnlock()
+	if ok {
+		return exact
+	}
+
+	if name, _, confidence := MatchLanguage(language); name != "" && confidence > 0 {
+		return name
+	}
+
+	parts := strings.Split(normalized, "_")
+	languageMu.RLock()
+	defer languageMu.RUnlock()
 	for len(parts) > 0 {
 		lang := strings.Join(parts, "_")
 		if name, ok := languagesLowercase[lang]; ok {
@@ -112,67 +163,67 @@ func (p *AlternativeParser) Parse(value int) interface{} {
 
 // HyphDict holds hyphenation patterns.
 type HyphDict struct {
-	patterns map[string]struct {
-		start  int
-		values []interface{}
-	}
-	cache  map[string][]hyphDataInt
-	maxlen int
+	// patternIndex maps a pattern's rolling hash to the (possibly several,
+	// on collision) patterns sharing that hash.
+	patternIndex map[uint64][]patternEntry
+	maxlen       int
+
+	cacheMu sync.RWMutex
+	cache   map[string][]hyphDataInt
+
+	// exceptions holds explicit break positions for individual words that
+	// must override the pattern scan, e.g. from a TeX \hyphenation{...}
+	// block. It is nil for dictionaries that don't have any.
+	exceptions map[string][]int
+
+	// leftMin and rightMin are the fewest runes that must remain on either
+	// side of a break in a plain word; the compound variants apply to each
+	// segment of a word that already contains an explicit hyphen.
+	leftMin, rightMin                 int
+	compoundLeftMin, compoundRightMin int
 }
 
-// NewHyphDict reads a hyph_*.dic file and parses its patterns.
+// NewHyphDict reads a hyph_*.dic file embedded in the package and parses
+// its patterns.
 func NewHyphDict(path string) (*HyphDict, error) {
-	hd := &HyphDict{
-		patterns: make(map[string]struct {
-			start  int
-			values []interface{}
-		}),
-		cache: make(map[string][]hyphDataInt),
-	}
-
-	file, err := dictionaries.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open dictionary file %s: %w", path, err)
-	}
-	defer file.Close()
-
-	data, err := io.ReadAll(file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read dictionary file %s: %w", path, err)
-	}
-
-	lines := strings.Split(string(data), "\n")
-	if len(lines) < 2 {
-		return nil, fmt.Errorf("invalid dictionary file format: %s", path)
-	}
+	return NewHyphDictFromFS(dictionaries, path)
+}
 
-	// First line is encoding, handle as in Python
-	encoding := strings.ToLower(strings.TrimSpace(lines[0]))
-	if encoding == "microsoft-cp1251" {
-		// Convert CP1251 encoded data to UTF-8
-		decoder := charmap.Windows1251.NewDecoder()
-		var convertedLines []string
-		convertedLines = append(convertedLines, lines[0]) // Keep the encoding line as-is
-
-		for _, line := range lines[1:] {
-			utf8Line, _, err := transform.String(decoder, line)
-			if err != nil {
-				// If conversion fails, use the original line
-				convertedLines = append(convertedLines, line)
-			} else {
-				convertedLines = append(convertedLines, utf8Line)
-			}
-		}
-		lines = convertedLines
+// newHyphDictFromPatternLines builds a HyphDict from dictionary body lines
+// (the encoding header and any transcoding must already be handled). It is
+// the parsing core shared by every NewHyphDictFrom* constructor.
+func newHyphDictFromPatternLines(lines []string) (*HyphDict, error) {
+	hd := &HyphDict{
+		patternIndex: make(map[uint64][]patternEntry),
+		cache:        make(map[string][]hyphDataInt),
 	}
 
 	var maxlen int
-	for _, pattern := range lines[1:] {
+	for _, pattern := range lines {
 		pattern = strings.TrimSpace(pattern)
 		if pattern == "" {
 			continue
 		}
 
+		if fields := strings.Fields(pattern); len(fields) == 2 {
+			if val, err := strconv.Atoi(fields[1]); err == nil {
+				switch fields[0] {
+				case "LEFTHYPHENMIN":
+					hd.leftMin = val
+					continue
+				case "RIGHTHYPHENMIN":
+					hd.rightMin = val
+					continue
+				case "COMPOUNDLEFTHYPHENMIN":
+					hd.compoundLeftMin = val
+					continue
+				case "COMPOUNDRIGHTHYPHENMIN":
+					hd.compoundRightMin = val
+					continue
+				}
+			}
+		}
+
 		isIgnored := false
 		for _, prefix := range ignored {
 			if strings.HasPrefix(pattern, prefix) {
@@ -246,70 +297,115 @@ func NewHyphDict(path string) (*HyphDict, error) {
 			maxlen = len(tags)
 		}
 
-		hd.patterns[strings.Join(tags, "")] = struct {
-			start  int
-			values []interface{}
-		}{
+		key := []rune(strings.Join(tags, ""))
+		h := hashRunes(key)
+		hd.patternIndex[h] = append(hd.patternIndex[h], patternEntry{
+			key:    key,
 			start:  start,
 			values: values[start:end],
-		}
+		})
 	}
 	hd.maxlen = maxlen
+
+	if hd.leftMin == 0 {
+		hd.leftMin = defaultHyphenMin
+	}
+	if hd.rightMin == 0 {
+		hd.rightMin = defaultHyphenMin
+	}
+	if hd.compoundLeftMin == 0 {
+		hd.compoundLeftMin = hd.leftMin
+	}
+	if hd.compoundRightMin == 0 {
+		hd.compoundRightMin = hd.rightMin
+	}
+
 	return hd, nil
 }
 
-// Positions gets a list of positions where the word can be hyphenated.
-func (hd *HyphDict) Positions(word string) []hyphDataInt {
+// rawPositions gets a list of positions where the word can be hyphenated,
+// keeping the nonstandard-hyphenation data attached to each break.
+func (hd *HyphDict) rawPositions(word string) []hyphDataInt {
 	word = strings.ToLower(word)
-	if points, ok := hd.cache[word]; ok {
+
+	hd.cacheMu.RLock()
+	points, ok := hd.cache[word]
+	hd.cacheMu.RUnlock()
+	if ok {
 		return points
 	}
 
-	pointedWord := "." + word + "."
-	references := make([]interface{}, utf8.RuneCountInString(pointedWord)+1)
+	if exceptionPositions, ok := hd.exceptions[word]; ok {
+		points = make([]hyphDataInt, len(exceptionPositions))
+		for i, p := range exceptionPositions {
+			points[i] = hyphDataInt{value: p}
+		}
+		hd.cacheMu.Lock()
+		hd.cache[word] = points
+		hd.cacheMu.Unlock()
+		return points
+	}
+
+	pointedWord := make([]rune, 0, len(word)+2)
+	pointedWord = append(pointedWord, '.')
+	pointedWord = append(pointedWord, []rune(word)...)
+	pointedWord = append(pointedWord, '.')
+	n := len(pointedWord)
+
+	references := make([]interface{}, n+1)
 
-	for i := 0; i < utf8.RuneCountInString(pointedWord)-1; i++ {
-		stop := min(i+hd.maxlen, utf8.RuneCountInString(pointedWord)) + 1
-		for j := i + 1; j < stop; j++ {
-			subWord := pointedWord[byteIndex(pointedWord, i):byteIndex(pointedWord, j)]
-			pattern, ok := hd.patterns[subWord]
-			if !ok {
+	for i := 0; i < n-1; i++ {
+		stop := min(i+hd.maxlen, n)
+		var h uint64
+		for j := i + 1; j <= stop; j++ {
+			// Extend the rolling hash by one rune instead of re-slicing
+			// and re-hashing pointedWord[i:j] from scratch every time.
+			h = extendHash(h, pointedWord[j-1])
+
+			candidates := hd.patternIndex[h]
+			if len(candidates) == 0 {
 				continue
 			}
+			window := pointedWord[i:j]
+			for _, entry := range candidates {
+				if !runesEqual(entry.key, window) {
+					continue
+				}
 
-			offset, values := pattern.start, pattern.values
-			for k, v := range values {
-				idx := i + offset + k
+				offset, values := entry.start, entry.values
+				for k, v := range values {
+					idx := i + offset + k
 
-				// Max logic
-				var current int
-				if currentRef, ok := references[idx].(int); ok {
-					current = currentRef
-				} else if currentRef, ok := references[idx].(hyphDataInt); ok {
-					current = currentRef.value
-				}
+					// Max logic
+					var current int
+					if currentRef, ok := references[idx].(int); ok {
+						current = currentRef
+					} else if currentRef, ok := references[idx].(hyphDataInt); ok {
+						current = currentRef.value
+					}
 
-				var patternVal int
-				var data []string
-				if patternRef, ok := v.(int); ok {
-					patternVal = patternRef
-				} else if patternRef, ok := v.(hyphDataInt); ok {
-					patternVal = patternRef.value
-					data = patternRef.data
-				}
+					var patternVal int
+					var data []string
+					if patternRef, ok := v.(int); ok {
+						patternVal = patternRef
+					} else if patternRef, ok := v.(hyphDataInt); ok {
+						patternVal = patternRef.value
+						data = patternRef.data
+					}
 
-				if patternVal > current {
-					if data != nil {
-						references[idx] = hyphDataInt{value: patternVal, data: data}
-					} else {
-						references[idx] = patternVal
+					if patternVal > current {
+						if data != nil {
+							references[idx] = hyphDataInt{value: patternVal, data: data}
+						} else {
+							references[idx] = patternVal
+						}
 					}
 				}
+				break
 			}
 		}
 	}
 
-	var points []hyphDataInt
 	for i, ref := range references {
 		val, isInt := ref.(int)
 		if isInt && val%2 != 0 {
@@ -320,7 +416,9 @@ func (hd *HyphDict) Positions(word string) []hyphDataInt {
 		}
 	}
 
+	hd.cacheMu.Lock()
 	hd.cache[word] = points
+	hd.cacheMu.Unlock()
 	return points
 }
 
@@ -329,7 +427,3 @@ func atoi(s string) int {
 	i, _ := strconv.Atoi(s)
 	return i
 }
-
-func byteIndex(s string, runeIndex int) int {
-	return len([]byte(s)) - len([]byte(s[runeIndex:]))
-}
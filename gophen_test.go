@@ -0,0 +1,130 @@
+package gophen
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustHyphDict(t *testing.T, data string) *HyphDict {
+	t.Helper()
+	hd, err := NewHyphDictFromReader(strings.NewReader(data), "")
+	if err != nil {
+		t.Fatalf("NewHyphDictFromReader: %v", err)
+	}
+	return hd
+}
+
+func TestPositionsInsertedIterateWrap(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\nLEFTHYPHENMIN 1\nRIGHTHYPHENMIN 1\n.ab1c.\na1n1\n")
+
+	if got, want := hd.Positions("anan"), []int{1, 2, 3}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v", "anan", got, want)
+	}
+
+	if got, want := hd.Inserted("anan", "-"), "a-n-a-n"; got != want {
+		t.Fatalf("Inserted(%q) = %q, want %q", "anan", got, want)
+	}
+
+	pairs := hd.Iterate("anan")
+	wantPairs := []Pair{{"ana", "n"}, {"an", "an"}, {"a", "nan"}}
+	if len(pairs) != len(wantPairs) {
+		t.Fatalf("Iterate(%q) = %v, want %v", "anan", pairs, wantPairs)
+	}
+	for i, p := range pairs {
+		if p != wantPairs[i] {
+			t.Fatalf("Iterate(%q)[%d] = %v, want %v", "anan", i, p, wantPairs[i])
+		}
+	}
+
+	head, tail, ok := hd.Wrap("anan", 3)
+	if !ok || head != "an-" || tail != "an" {
+		t.Fatalf("Wrap(%q, 3) = (%q, %q, %v), want (\"an-\", \"an\", true)", "anan", head, tail, ok)
+	}
+}
+
+func TestLeftRightHyphenMinFiltering(t *testing.T) {
+	// No LEFTHYPHENMIN/RIGHTHYPHENMIN directives, so the libhyphen default
+	// of 2 runes from either edge applies.
+	hd := mustHyphDict(t, "UTF-8\n.ab1c.\na1n1\n")
+
+	if got, want := hd.Positions("anan"), []int{2}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v (default min should drop edge breaks)", "anan", got, want)
+	}
+
+	if got, want := hd.Positions("anan", WithLeftMin(1), WithRightMin(1)), []int{1, 2, 3}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) with WithLeftMin/WithRightMin = %v, want %v", "anan", got, want)
+	}
+}
+
+func TestCompoundPositionsData(t *testing.T) {
+	// COMPOUNDLEFTHYPHENMIN/COMPOUNDRIGHTHYPHENMIN are stricter than the
+	// plain LEFTHYPHENMIN/RIGHTHYPHENMIN, so a compound word must filter
+	// differently per segment than a plain word of the same text would.
+	hd := mustHyphDict(t, "UTF-8\nLEFTHYPHENMIN 1\nRIGHTHYPHENMIN 1\n"+
+		"COMPOUNDLEFTHYPHENMIN 2\nCOMPOUNDRIGHTHYPHENMIN 2\n.ab1c.\na1n1\n")
+
+	if got, want := hd.Positions("anan"), []int{1, 2, 3}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v (plain word uses LEFTHYPHENMIN/RIGHTHYPHENMIN)", "anan", got, want)
+	}
+
+	// "anan-anan" hyphenates each 4-rune segment independently; with a
+	// compound min of 2 on either side, only the middle break of each
+	// segment survives, and the second segment's break must be offset
+	// past the first segment plus the "-" separator (index 5).
+	if got, want := hd.Positions("anan-anan"), []int{2, 7}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v (compound min should filter per segment, offsets should skip the separator)", "anan-anan", got, want)
+	}
+}
+
+func TestNonstandardHyphenInserted(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\nLEFTHYPHENMIN 1\nRIGHTHYPHENMIN 1\nck1/k=k,1,1\n")
+
+	breaks := hd.PositionsData("backen")
+	if len(breaks) == 0 || breaks[0].Nonstandard == nil {
+		t.Fatalf("PositionsData(%q) = %v, want at least one nonstandard break", "backen", breaks)
+	}
+
+	// Inserted must not panic when a nonstandard break rewrites letters,
+	// and its output must agree with what Iterate reconstructs for the
+	// same break.
+	inserted := hd.Inserted("backen", "-")
+	pairs := hd.Iterate("backen")
+	want := pairs[len(pairs)-1].Prefix + "-" + pairs[len(pairs)-1].Suffix
+	if inserted != want {
+		t.Fatalf("Inserted(%q) = %q, want %q (from Iterate)", "backen", inserted, want)
+	}
+}
+
+func TestTeXHyphenationException(t *testing.T) {
+	tex := `% comment
+\patterns{
+.ab1c.
+a1n1
+}
+\hyphenation{
+man-u-script
+}
+`
+	hd, err := NewHyphDictFromTeX(strings.NewReader(tex))
+	if err != nil {
+		t.Fatalf("NewHyphDictFromTeX: %v", err)
+	}
+
+	// The explicit exception overrides the pattern scan, which on its own
+	// would break "manuscript" at 2 and 3 (a1n1 matches the "an" in "man").
+	if got, want := hd.Positions("manuscript"), []int{3, 4}; !intsEqual(got, want) {
+		t.Fatalf("Positions(%q) = %v, want %v", "manuscript", got, want)
+	}
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+package gophen
+
+import (
+	"strconv"
+	"strings"
+)
+
+// softHyphen is the separator Inserted uses when the caller passes "".
+const softHyphen = "­"
+
+// NonstandardHyphen describes a libhyphen "change,index,cut" substitution
+// that applies at a break position, e.g. German "backen" -> "bak-ken".
+type NonstandardHyphen struct {
+	Change string
+	Index  int
+	Cut    int
+}
+
+// Break is a single legal hyphenation point in a word.
+type Break struct {
+	// Index is the split position: word[:Index] and word[Index:] are the
+	// two halves a plain (non-nonstandard) break produces.
+	Index int
+	// Nonstandard is non-nil when the break rewrites the surrounding
+	// letters instead of simply inserting a hyphen between them.
+	Nonstandard *NonstandardHyphen
+}
+
+// Pair is one way of splitting a word at a legal hyphenation point.
+type Pair struct {
+	Prefix string
+	Suffix string
+}
+
+// Positions returns the rune split positions where word may be hyphenated
+// (word[:pos] / word[pos:] for each pos), ordered from left to right. By
+// default no break is returned within HyphDict's LEFTHYPHENMIN/
+// RIGHTHYPHENMIN of either edge of the word (or the compound variants, for
+// each segment of a hyphenated compound); pass WithLeftMin/WithRightMin to
+// override per call.
+func (hd *HyphDict) Positions(word string, opts ...Option) []int {
+	breaks := hd.PositionsData(word, opts...)
+	positions := make([]int, len(breaks))
+	for i, b := range breaks {
+		positions[i] = b.Index
+	}
+	return positions
+}
+
+// PositionsData returns the same breaks as Positions, plus the
+// nonstandard-hyphenation metadata for breaks that rewrite letters rather
+// than simply inserting a hyphen.
+func (hd *HyphDict) PositionsData(word string, opts ...Option) []Break {
+	if segments := strings.Split(word, "-"); len(segments) > 1 {
+		return hd.compoundPositionsData(segments, opts)
+	}
+
+	m := hd.resolveMin(opts)
+	runes := []rune(word)
+	var breaks []Break
+	for _, r := range hd.rawPositions(word) {
+		if !m.allows(r.value, len(runes)) {
+			continue
+		}
+		breaks = append(breaks, toBreak(r, 0))
+	}
+	return breaks
+}
+
+// compoundPositionsData applies the compound minimum break distances to
+// each hyphen-separated segment of word independently, then offsets each
+// segment's breaks back into word's rune positions.
+func (hd *HyphDict) compoundPositionsData(segments []string, opts []Option) []Break {
+	m := hd.resolveCompoundMin(opts)
+	var breaks []Break
+	offset := 0
+	for _, seg := range segments {
+		segRunes := []rune(seg)
+		for _, r := range hd.rawPositions(seg) {
+			if !m.allows(r.value, len(segRunes)) {
+				continue
+			}
+			breaks = append(breaks, toBreak(r, offset))
+		}
+		offset += len(segRunes) + 1 // +1 skips the '-' separator
+	}
+	return breaks
+}
+
+// allows reports whether a break at index (the split position: prefix is
+// the first index runes of the word) leaves at least m.left runes before
+// it and m.right runes after it in a word of the given rune length.
+func (m hyphenMin) allows(index, runeLen int) bool {
+	return index >= m.left && runeLen-index >= m.right
+}
+
+func toBreak(r hyphDataInt, offset int) Break {
+	b := Break{Index: offset + r.value}
+	if r.data != nil {
+		idx, _ := strconv.Atoi(r.data[1])
+		cut, _ := strconv.Atoi(r.data[2])
+		b.Nonstandard = &NonstandardHyphen{Change: r.data[0], Index: idx, Cut: cut}
+	}
+	return b
+}
+
+// nonstandardSpan returns the rune range [start, end) of word that a
+// nonstandard break's "change,index,cut" transform replaces, along with the
+// literal text (left, right) that goes before and after the break within
+// that range. ok is false if the break's cut runs past the end of word, in
+// which case the break must be treated as if it had no nonstandard data.
+func nonstandardSpan(word string, b Break) (start, end int, left, right string, ok bool) {
+	ns := b.Nonstandard
+	left, right = ns.Change, ""
+	if idx := strings.IndexByte(ns.Change, '='); idx >= 0 {
+		left, right = ns.Change[:idx], ns.Change[idx+1:]
+	}
+	start = b.Index + ns.Index
+	if start < 0 {
+		start = 0
+	}
+	end = start + ns.Cut
+	return start, end, left, right, end <= len([]rune(word))
+}
+
+// split rebuilds the prefix/suffix pair for a single break, applying the
+// nonstandard-hyphenation "change,index,cut" transform when present so the
+// reconstructed halves match what libhyphen would print rather than just
+// the raw break offset.
+func split(word string, b Break) Pair {
+	runes := []rune(word)
+	if b.Nonstandard == nil {
+		return Pair{Prefix: string(runes[:b.Index]), Suffix: string(runes[b.Index:])}
+	}
+
+	if start, end, left, right, ok := nonstandardSpan(word, b); ok {
+		return Pair{
+			Prefix: string(runes[:start]) + left,
+			Suffix: right + string(runes[end:]),
+		}
+	}
+	return Pair{Prefix: string(runes[:b.Index]), Suffix: string(runes[b.Index:])}
+}
+
+// Iterate returns every legal (prefix, suffix) split of word, ordered from
+// the longest prefix to the shortest.
+func (hd *HyphDict) Iterate(word string, opts ...Option) []Pair {
+	breaks := hd.PositionsData(word, opts...)
+	pairs := make([]Pair, len(breaks))
+	for i, b := range breaks {
+		pairs[len(breaks)-1-i] = split(word, b)
+	}
+	return pairs
+}
+
+// Inserted returns word with sep inserted at every legal hyphenation point.
+// sep defaults to the soft hyphen U+00AD when empty.
+func (hd *HyphDict) Inserted(word, sep string, opts ...Option) string {
+	if sep == "" {
+		sep = softHyphen
+	}
+	breaks := hd.PositionsData(word, opts...)
+	runes := []rune(word)
+	var out strings.Builder
+	last := 0
+	for _, brk := range breaks {
+		start, end, left, right := brk.Index, brk.Index, "", ""
+		if brk.Nonstandard != nil {
+			if s, e, l, r, ok := nonstandardSpan(word, brk); ok {
+				start, end, left, right = s, e, l, r
+			}
+		}
+		if start < last {
+			continue
+		}
+		out.WriteString(string(runes[last:start]))
+		out.WriteString(left)
+		out.WriteString(sep)
+		out.WriteString(right)
+		last = end
+	}
+	out.WriteString(string(runes[last:]))
+	return out.String()
+}
+
+// Wrap returns the longest legal break of word whose prefix (plus a
+// trailing hyphen) fits within width runes. ok is false if no break fits.
+func (hd *HyphDict) Wrap(word string, width int, opts ...Option) (head, tail string, ok bool) {
+	for _, pair := range hd.Iterate(word, opts...) {
+		if len([]rune(pair.Prefix))+1 <= width {
+			return pair.Prefix + "-", pair.Suffix, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,66 @@
+package gophen
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	languageMatcher    language.Matcher
+	languageMatchNames []string
+)
+
+// rebuildLanguageMatcherLocked rebuilds the BCP-47 matcher from every
+// dictionary tag known so far. It is called after populateLanguages
+// discovers the embedded dictionaries and again whenever RegisterLanguage
+// adds one. The caller must hold languageMu for writing.
+func rebuildLanguageMatcherLocked() {
+	names := make([]string, 0, len(dictionaryTags))
+	for name := range dictionaryTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tags := make([]language.Tag, 0, len(names))
+	matchNames := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		matchNames = append(matchNames, name)
+	}
+
+	languageMatchNames = matchNames
+	languageMatcher = language.NewMatcher(tags)
+}
+
+// MatchLanguage resolves the best dictionary for a mix of BCP-47 tags
+// (e.g. "en-GB", "pt-BR", "sr-Latn-RS", "zh-Hant-HK"), using
+// golang.org/x/text/language's script- and region-aware matching. It
+// returns the matched dictionary name, the matched tag, and the matcher's
+// confidence in that match.
+func MatchLanguage(tags ...string) (string, language.Tag, language.Confidence) {
+	parsed := make([]language.Tag, 0, len(tags))
+	for _, t := range tags {
+		if tag, err := language.Parse(t); err == nil {
+			parsed = append(parsed, tag)
+		}
+	}
+	if len(parsed) == 0 {
+		return "", language.Und, language.No
+	}
+
+	languageMu.RLock()
+	defer languageMu.RUnlock()
+
+	if languageMatcher == nil || len(languageMatchNames) == 0 {
+		return "", language.Und, language.No
+	}
+
+	tag, index, confidence := languageMatcher.Match(parsed...)
+	return languageMatchNames[index], tag, confidence
+}
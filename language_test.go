@@ -0,0 +1,71 @@
+package gophen
+
+import (
+	"strings"
+	"testing"
+)
+
+// registerTestLanguage is RegisterLanguage plus a t.Cleanup that removes
+// name again, so tests can seed dictionaryTags/LANGUAGES without leaking
+// state into other tests that share these package-level globals.
+func registerTestLanguage(t *testing.T, name, alias string, hd *HyphDict) {
+	t.Helper()
+	shortName := strings.Split(name, "_")[0]
+	languageMu.RLock()
+	_, hadShortName := LANGUAGES[shortName]
+	languageMu.RUnlock()
+	RegisterLanguage(name, alias, hd)
+	t.Cleanup(func() {
+		hdcache.Delete(name)
+		languageMu.Lock()
+		defer languageMu.Unlock()
+		if !hadShortName {
+			delete(LANGUAGES, shortName)
+		}
+		delete(dictionaryTags, name)
+		delete(languagesLowercase, strings.ToLower(name))
+		if alias != "" {
+			delete(languagesLowercase, strings.ToLower(alias))
+		}
+		rebuildLanguageMatcherLocked()
+	})
+}
+
+func TestMatchLanguagePicksRegionVariant(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\na1n1\n")
+	registerTestLanguage(t, "en_GB", "", hd)
+	registerTestLanguage(t, "en_US", "", hd)
+
+	name, _, confidence := MatchLanguage("en-AU")
+	if confidence == 0 {
+		t.Fatalf("MatchLanguage(%q) confidence = %v, want > 0", "en-AU", confidence)
+	}
+	if name != "en_GB" {
+		t.Fatalf("MatchLanguage(%q) = %q, want %q", "en-AU", name, "en_GB")
+	}
+}
+
+func TestLanguageFallbackUsesBCP47MatchOverBareBaseCode(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\na1n1\n")
+	registerTestLanguage(t, "en_GB", "", hd)
+	registerTestLanguage(t, "en_US", "", hd)
+
+	// "en" alone (no region variants registered under it) must not win the
+	// lexical prefix-trim before MatchLanguage gets a chance to pick the
+	// proper region.
+	if got, want := LanguageFallback("en-AU"), "en_GB"; got != want {
+		t.Fatalf("LanguageFallback(%q) = %q, want %q", "en-AU", got, want)
+	}
+}
+
+func TestLanguageFallbackExactRegisteredName(t *testing.T) {
+	hd := mustHyphDict(t, "UTF-8\na1n1\n")
+	registerTestLanguage(t, "de_DE_custom", "bavarian", hd)
+
+	if got, want := LanguageFallback("de_DE_custom"), "de_DE_custom"; got != want {
+		t.Fatalf("LanguageFallback(%q) = %q, want %q", "de_DE_custom", got, want)
+	}
+	if got, want := LanguageFallback("bavarian"), "de_DE_custom"; got != want {
+		t.Fatalf("LanguageFallback(%q) = %q, want %q", "bavarian", got, want)
+	}
+}
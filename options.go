@@ -0,0 +1,36 @@
+package gophen
+
+// hyphenMin holds the resolved minimum break distances for a single call.
+type hyphenMin struct {
+	left  int
+	right int
+}
+
+// Option overrides a HyphDict's minimum break distances for a single call.
+type Option func(*hyphenMin)
+
+// WithLeftMin overrides the fewest runes required before a break.
+func WithLeftMin(n int) Option {
+	return func(m *hyphenMin) { m.left = n }
+}
+
+// WithRightMin overrides the fewest runes required after a break.
+func WithRightMin(n int) Option {
+	return func(m *hyphenMin) { m.right = n }
+}
+
+func (hd *HyphDict) resolveMin(opts []Option) hyphenMin {
+	m := hyphenMin{left: hd.leftMin, right: hd.rightMin}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+func (hd *HyphDict) resolveCompoundMin(opts []Option) hyphenMin {
+	m := hyphenMin{left: hd.compoundLeftMin, right: hd.compoundRightMin}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
@@ -0,0 +1,41 @@
+package gophen
+
+// patternEntry is one libhyphen pattern, keyed in HyphDict.patternIndex by
+// the rolling hash of key so rawPositions can look candidates up without
+// allocating a substring (and re-hashing it) for every rune span it tries.
+type patternEntry struct {
+	key    []rune
+	start  int
+	values []interface{}
+}
+
+// hashBase is the multiplier for the rolling polynomial hash used to index
+// patterns. It need not be prime or collision-proof on its own: every hash
+// hit is verified against the candidate's key with runesEqual before use.
+const hashBase = 131
+
+// hashRunes hashes a full pattern key when building the index.
+func hashRunes(runes []rune) uint64 {
+	var h uint64
+	for _, r := range runes {
+		h = extendHash(h, r)
+	}
+	return h
+}
+
+// extendHash extends a rolling hash by one rune.
+func extendHash(h uint64, r rune) uint64 {
+	return h*hashBase + uint64(r)
+}
+
+func runesEqual(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package gophen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// NewHyphDictFromTeX parses a Knuth/Liang TeX hyphenation pattern file, as
+// shipped by CTAN/hyph-utf8: a \patterns{...} block using the same
+// digit-weighted pattern syntax as libhyphen (minus its "/" nonstandard
+// alternative), plus an optional \hyphenation{...} block of exception
+// words whose explicit "-"s are stored in HyphDict.exceptions and
+// consulted by rawPositions before any pattern scan runs.
+func NewHyphDictFromTeX(r io.Reader) (*HyphDict, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TeX pattern data: %w", err)
+	}
+	text := stripTeXComments(string(data))
+
+	patternsBody, err := extractTeXBlock(text, `\patterns`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find \\patterns block: %w", err)
+	}
+
+	hd, err := newHyphDictFromPatternLines(strings.Fields(patternsBody))
+	if err != nil {
+		return nil, err
+	}
+
+	if hyphenationBody, err := extractTeXBlock(text, `\hyphenation`); err == nil {
+		hd.exceptions = make(map[string][]int)
+		for _, word := range strings.Fields(hyphenationBody) {
+			key, positions := parseHyphenationException(word)
+			hd.exceptions[key] = positions
+		}
+	}
+
+	return hd, nil
+}
+
+// LoadDict loads a dictionary from path, auto-detecting whether it is a
+// libhyphen hyph_*.dic file or a TeX \patterns/\hyphenation pattern file
+// from its first non-blank line.
+func LoadDict(path string) (*HyphDict, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary file %s: %w", path, err)
+	}
+
+	var firstLine string
+	for _, line := range strings.Split(string(data), "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			firstLine = trimmed
+			break
+		}
+	}
+
+	if strings.Contains(firstLine, `\patterns`) || strings.HasPrefix(firstLine, "%") {
+		hd, err := NewHyphDictFromTeX(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse TeX dictionary %s: %w", path, err)
+		}
+		return hd, nil
+	}
+
+	return NewHyphDictFromFile(path)
+}
+
+// stripTeXComments blanks out everything from an unescaped % to the end of
+// each line.
+func stripTeXComments(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		backslashes := 0
+		for j := 0; j < len(line); j++ {
+			if line[j] == '%' && backslashes%2 == 0 {
+				lines[i] = line[:j]
+				break
+			}
+			if line[j] == '\\' {
+				backslashes++
+			} else {
+				backslashes = 0
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// extractTeXBlock returns the brace-delimited body following the first
+// occurrence of command in text, e.g. extractTeXBlock(text, `\patterns`)
+// returns the inside of \patterns{ ... }.
+func extractTeXBlock(text, command string) (string, error) {
+	idx := strings.Index(text, command)
+	if idx < 0 {
+		return "", fmt.Errorf("%s not found", command)
+	}
+	rest := text[idx+len(command):]
+
+	start := strings.IndexByte(rest, '{')
+	if start < 0 {
+		return "", fmt.Errorf("%s has no opening brace", command)
+	}
+
+	depth := 0
+	for i := start; i < len(rest); i++ {
+		switch rest[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return rest[start+1 : i], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s has no matching closing brace", command)
+}
+
+// parseHyphenationException turns a \hyphenation{...} entry like
+// "man-u-script" into its lowercased, unhyphenated key and the rune split
+// positions its "-"s mark.
+func parseHyphenationException(word string) (key string, positions []int) {
+	segments := strings.Split(word, "-")
+	positions = make([]int, 0, len(segments)-1)
+
+	var b strings.Builder
+	for i, seg := range segments {
+		b.WriteString(seg)
+		if i < len(segments)-1 {
+			positions = append(positions, len([]rune(b.String())))
+		}
+	}
+	return strings.ToLower(b.String()), positions
+}
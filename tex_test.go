@@ -0,0 +1,38 @@
+package gophen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDictAutoDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	dicPath := filepath.Join(dir, "hyph_xx.dic")
+	if err := os.WriteFile(dicPath, []byte("UTF-8\n.ab1c.\na1n1\n"), 0o644); err != nil {
+		t.Fatalf("writing .dic fixture: %v", err)
+	}
+
+	texPath := filepath.Join(dir, "hyph-xx.tex")
+	tex := "% comment\n\\patterns{\n.ab1c.\na1n1\n}\n"
+	if err := os.WriteFile(texPath, []byte(tex), 0o644); err != nil {
+		t.Fatalf("writing .tex fixture: %v", err)
+	}
+
+	for _, path := range []string{dicPath, texPath} {
+		hd, err := LoadDict(path)
+		if err != nil {
+			t.Fatalf("LoadDict(%q): %v", path, err)
+		}
+		if got, want := hd.Positions("anan", WithLeftMin(1), WithRightMin(1)), []int{1, 2, 3}; !intsEqual(got, want) {
+			t.Fatalf("LoadDict(%q).Positions(%q) = %v, want %v", path, "anan", got, want)
+		}
+	}
+}
+
+func TestLoadDictMissingFile(t *testing.T) {
+	if _, err := LoadDict(filepath.Join(t.TempDir(), "missing.dic")); err == nil {
+		t.Fatal("LoadDict on a missing file = nil error, want an error")
+	}
+}